@@ -3,12 +3,21 @@ package compiler
 import (
 	"io"
 
-	. "github.com/ReewassSquared/ReeCurse/compiler/lexer"
+	. "github.com/ReewassSquared/ReeCurse/compiler/optimize"
 	. "github.com/ReewassSquared/ReeCurse/compiler/parser"
 )
 
+type Options struct {
+	/* check values of unknown type at runtime where typed code uses them. */
+	Gradual bool
+}
+
 func Compile(rin io.Reader, rout io.Writer) {
-	p := &ReeParser{}
+	CompileWith(rin, rout, Options{})
+}
+
+func CompileWith(rin io.Reader, rout io.Writer, opts Options) {
+	p := &ReeParser{Gradual: opts.Gradual}
 	p.Parse(rin)
-	
+	p.Node = Fold(p.Node)
 }
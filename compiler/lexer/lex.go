@@ -52,7 +52,7 @@ func (l *ReeLexer) Init(r io.Reader) {
 	l.bsize = LexBufferMin
 	l.mode = LEXMODE_NORMAL
 
-	l.mstack = make([]reemodes, 4)
+	l.mstack = make([]reemodes, 0, 4)
 	l.mstack = append(l.mstack, reemodes{mode: LEXMODE_NORMAL, depth: 0})
 }
 
@@ -257,9 +257,19 @@ redonextn:
 		/* increment */
 		l.mstack[len(l.mstack)-1].depth++
 		break
-	case ')', ']':
+	case '{':
 		l.nextch()
-		l.Tok = l.makeToken(TOK_RPAREN, "")
+		l.Tok = l.makeToken(TOK_LBRACE, "")
+		/* increment */
+		l.mstack[len(l.mstack)-1].depth++
+		break
+	case ')', ']', '}':
+		tok := TOK_RPAREN
+		if l.ch == '}' {
+			tok = TOK_RBRACE
+		}
+		l.nextch()
+		l.Tok = l.makeToken(tok, "")
 		/* increment */
 		l.mstack[len(l.mstack)-1].depth--
 		if l.mstack[len(l.mstack)-1].depth <= 0 {
@@ -279,7 +289,7 @@ redonextn:
 			l.number()
 			break
 		}
-		l.Tok = l.makeOp(OP_ADD, "")
+		l.Tok = l.makeOp(OP_ADD, "+")
 		break
 	case '-':
 		l.nextch()
@@ -287,15 +297,20 @@ redonextn:
 			l.number()
 			break
 		}
-		l.Tok = l.makeOp(OP_SUB, "")
+		if l.ch == '>' {
+			/* the arrow of function types, e.g. (-> int bool) */
+			l.ident()
+			break
+		}
+		l.Tok = l.makeOp(OP_SUB, "-")
 		break
 	case '*':
 		l.nextch()
-		l.Tok = l.makeOp(OP_MUL, "")
+		l.Tok = l.makeOp(OP_MUL, "*")
 		break
 	case '/':
 		l.nextch()
-		l.Tok = l.makeOp(OP_DIV, "")
+		l.Tok = l.makeOp(OP_DIV, "/")
 		break
 	case '"':
 		l.nextch()
@@ -305,27 +320,27 @@ redonextn:
 		l.nextch()
 		if l.ch == '=' {
 			l.nextch()
-			l.Tok = l.makeOp(OP_GTEQ, "")
+			l.Tok = l.makeOp(OP_GTEQ, ">=")
 			break
 		}
-		l.Tok = l.makeOp(OP_GT, "")
+		l.Tok = l.makeOp(OP_GT, ">")
 		break
 	case '<':
 		l.nextch()
 		if l.ch == '=' {
 			l.nextch()
-			l.Tok = l.makeOp(OP_LTEQ, "")
+			l.Tok = l.makeOp(OP_LTEQ, "<=")
 			break
 		}
-		l.Tok = l.makeOp(OP_LT, "")
+		l.Tok = l.makeOp(OP_LT, "<")
 		break
 	case '=':
 		l.nextch()
-		l.Tok = l.makeOp(OP_EQ, "")
+		l.Tok = l.makeOp(OP_EQ, "=")
 		break
 	case '~':
 		l.nextch()
-		l.Tok = l.makeOp(OP_NEQ, "")
+		l.Tok = l.makeOp(OP_NEQ, "~")
 		break
 	case '\'':
 		l.nextch()
@@ -370,6 +385,13 @@ redonextn:
 			l.nextch()
 			l.Tok = l.makeToken(TOK_SUPRESS, "")
 			break
+		} else if l.ch == '(' {
+			/* vector literal */
+			l.nextch()
+			l.Tok = l.makeToken(TOK_LVECTOR, "")
+			/* increment */
+			l.mstack[len(l.mstack)-1].depth++
+			break
 		} else if l.ch == '\\' {
 			/* literal character */
 			l.start()
@@ -466,8 +488,6 @@ func (l *ReeLexer) number() {
 }
 
 func (l *ReeLexer) qstring() {
-	l.nextch()
-
 	for {
 		if l.ch == '"' {
 			l.nextch()
@@ -510,12 +530,14 @@ func (l *ReeLexer) IsEscape(quote rune) bool {
 		return true
 	case '0', '1', '2', '3', '4', '5', '6', '7':
 		n, base, max = 3, 8, 255
-		//TODO <-- find way to trigger this condition in strings test
 	case 'x':
+		l.nextch()
 		n, base, max = 2, 16, 255
 	case 'u':
+		l.nextch()
 		n, base, max = 4, 16, unicode.MaxRune
 	case 'U':
+		l.nextch()
 		n, base, max = 8, 16, unicode.MaxRune
 	default:
 		if l.ch < 0 {
@@ -542,6 +564,9 @@ func (l *ReeLexer) IsEscape(quote rune) bool {
 		}
 		// d < base
 		x = x*base + d
+		if i > 1 {
+			l.nextch() // caller steps past the final digit
+		}
 	}
 
 	if x > max && base == 8 {
@@ -572,6 +597,10 @@ func (l *ReeLexer) ident() {
 		l.Tok = l.makeKeyword(val, string(l.segment()))
 		return
 	}
+	if val, ok := builtins[string(l.segment())]; ok {
+		l.Tok = l.makeOp(val, string(l.segment()))
+		return
+	}
 	l.Tok = l.makeToken(TOK_IDENT, string(l.segment()))
 }
 
@@ -589,32 +618,64 @@ func baseName(base int) string {
 	panic("invalid base")
 }
 
+/**
+ * Characters are one of the following:
+ * #\<any single rune>           e.g. #\a #\( #\λ
+ * #\<name>                      e.g. #\space #\newline (see charnames)
+ * #\x<hex>                      R7RS hex scalar value, e.g. #\x41
+ * #\u<hex>, #\U<hex>            kept for compatibility with Go escapes
+ */
 func (l *ReeLexer) char() {
-	/* expect any unicode letter or unicode sequence. */
+	if l.ch < 0 {
+		l.errorf("character literal not terminated")
+		l.Tok = l.makeRune(utf8.RuneError)
+		return
+	}
+	/* always take one rune, so delimiters like #\( are valid characters. */
+	l.nextch()
 	for l.ch != -1 && !endseq(l.ch) {
 		l.nextch()
 	}
 
-	scval := string(l.segment())
-	var val rune
-	var err error
-	if len(scval) <= 1 {
-		l.errorf("invalid character encountered")
+	scval := string(l.segment()[1:]) //drop the backslash
+	if utf8.RuneCountInString(scval) == 1 {
+		val, _ := utf8.DecodeRuneInString(scval)
+		l.Tok = l.makeRune(val)
 		return
 	}
-	if scval[1] != 'u' && len(scval) == 2 {
-		val = l.ch //should be most recent value
-	} else {
-		val, _, _, err = strconv.UnquoteChar(scval, '"')
+	if val, ok := charnames[scval]; ok {
+		l.Tok = l.makeRune(val)
+		return
 	}
-	if err != nil {
-		l.errorf("unexpected character parsing error: " + err.Error())
+	if scval[0] == 'x' || scval[0] == 'u' || scval[0] == 'U' {
+		x, err := strconv.ParseUint(scval[1:], 16, 32)
+		if err == nil {
+			if x > unicode.MaxRune || 0xD800 <= x && x < 0xE000 /* surrogate range */ {
+				l.errorf(fmt.Sprintf("character #\\%s is invalid Unicode code point %#U", scval, x))
+				l.Tok = l.makeRune(utf8.RuneError)
+				return
+			}
+			l.Tok = l.makeRune(rune(x))
+			return
+		}
 	}
-	l.Tok = l.makeRune(val)
+	l.errorf(fmt.Sprintf("unknown character name: #\\%s", scval))
+	l.Tok = l.makeRune(utf8.RuneError)
+}
+
+/* CharLiteral renders r in #\ syntax such that char() reads it back unchanged. */
+func CharLiteral(r rune) string {
+	if name, ok := runenames[r]; ok {
+		return "#\\" + name
+	}
+	if unicode.IsPrint(r) && !unicode.IsSpace(r) {
+		return "#\\" + string(r)
+	}
+	return fmt.Sprintf("#\\x%x", r)
 }
 
 func endseq(ch rune) bool {
-	return whitespace(ch) || ch == '[' || ch == ']' || ch == '(' || ch == ')' || ch == '#' || ch == '`' || ch == '\'' || ch == '"'
+	return whitespace(ch) || ch == '[' || ch == ']' || ch == '(' || ch == ')' || ch == '{' || ch == '}' || ch == '#' || ch == '`' || ch == '\'' || ch == '"'
 }
 
 func (l ReeLexer) makeToken(tok ReeToken, val string) Token {
@@ -692,9 +753,19 @@ func (l *ReeLexer) nextq() {
 		/* increment */
 		l.mstack[len(l.mstack)-1].depth++
 		break
-	case ')', ']':
+	case '{':
+		l.nextch()
+		l.Tok = l.makeToken(TOK_LBRACE, "")
+		/* increment */
+		l.mstack[len(l.mstack)-1].depth++
+		break
+	case ')', ']', '}':
+		tok := TOK_RPAREN
+		if l.ch == '}' {
+			tok = TOK_RBRACE
+		}
 		l.nextch()
-		l.Tok = l.makeToken(TOK_RPAREN, "")
+		l.Tok = l.makeToken(tok, "")
 		/* increment */
 		l.mstack[len(l.mstack)-1].depth--
 		if l.mstack[len(l.mstack)-1].depth <= 0 {
@@ -728,6 +799,13 @@ func (l *ReeLexer) nextq() {
 			l.nextch()
 			l.Tok = l.makeToken(SYM_FALSE, "")
 			break
+		} else if l.ch == '(' {
+			/* vector literal */
+			l.nextch()
+			l.Tok = l.makeToken(TOK_LVECTOR, "")
+			/* increment */
+			l.mstack[len(l.mstack)-1].depth++
+			break
 		} else if l.ch == '\\' {
 			/* literal character */
 			l.start()
@@ -788,9 +866,19 @@ func (l *ReeLexer) nextqq() {
 		/* increment */
 		l.mstack[len(l.mstack)-1].depth++
 		break
-	case ')', ']':
+	case '{':
+		l.nextch()
+		l.Tok = l.makeToken(TOK_LBRACE, "")
+		/* increment */
+		l.mstack[len(l.mstack)-1].depth++
+		break
+	case ')', ']', '}':
+		tok := TOK_RPAREN
+		if l.ch == '}' {
+			tok = TOK_RBRACE
+		}
 		l.nextch()
-		l.Tok = l.makeToken(TOK_RPAREN, "")
+		l.Tok = l.makeToken(tok, "")
 		/* increment */
 		l.mstack[len(l.mstack)-1].depth--
 		if l.mstack[len(l.mstack)-1].depth <= 0 {
@@ -837,6 +925,13 @@ func (l *ReeLexer) nextqq() {
 			l.nextch()
 			l.Tok = l.makeToken(SYM_FALSE, "")
 			break
+		} else if l.ch == '(' {
+			/* vector literal */
+			l.nextch()
+			l.Tok = l.makeToken(TOK_LVECTOR, "")
+			/* increment */
+			l.mstack[len(l.mstack)-1].depth++
+			break
 		} else if l.ch == '\\' {
 			/* literal character */
 			l.start()
@@ -0,0 +1,101 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func lexAll(src string) []Token {
+	l := &ReeLexer{}
+	l.Init(strings.NewReader(src))
+	toks := []Token{}
+	for tok := l.Next(); tok.Tok != TOK_EOF; tok = l.Next() {
+		toks = append(toks, *tok)
+	}
+	return toks
+}
+
+func TestCharLiterals(t *testing.T) {
+	tests := []struct {
+		src  string
+		want rune
+	}{
+		{`#\a`, 'a'},
+		{`#\λ`, 'λ'},
+		{`#\x`, 'x'},
+		{`#\(`, '('},
+		{`#\)`, ')'},
+		{`#\space`, ' '},
+		{`#\newline`, '\n'},
+		{`#\tab`, '\t'},
+		{`#\nul`, 0},
+		{`#\null`, 0},
+		{`#\alarm`, '\a'},
+		{`#\delete`, 0x7f},
+		{`#\escape`, 0x1b},
+		{`#\x41`, 'A'},
+		{`#\x3bb`, 'λ'},
+		{`#\u0041`, 'A'},
+		{`#\U0001F600`, 0x1F600},
+		{`#\xD800`, utf8.RuneError}, // surrogate
+		{`#\x110000`, utf8.RuneError},
+		{`#\bogus`, utf8.RuneError},
+	}
+	for _, tt := range tests {
+		toks := lexAll(tt.src)
+		if len(toks) != 1 || toks[0].Tok != TOK_LITCHAR {
+			t.Errorf("%s lexed as %v; want one TOK_LITCHAR", tt.src, toks)
+			continue
+		}
+		if toks[0].CVal != tt.want {
+			t.Errorf("%s = %U; want %U", tt.src, toks[0].CVal, tt.want)
+		}
+	}
+
+	/* a character literal ends at a delimiter. */
+	toks := lexAll(`(#\a)`)
+	if len(toks) != 3 || toks[1].CVal != 'a' || toks[2].Tok != TOK_RPAREN {
+		t.Errorf("(#\\a) lexed as %v", toks)
+	}
+}
+
+func TestCharLiteralRoundTrip(t *testing.T) {
+	runes := []rune{'a', 'Z', '(', ')', '#', '\\', 'λ', 'x', 'u', ' ', '\n', '\t', '\r', 0, 0x7f, 0x1b, '\a', '\b', 0xa0, 0x200b, 0x1F600, 0x10FFFF}
+	for _, r := range runes {
+		src := CharLiteral(r)
+		toks := lexAll(src)
+		if len(toks) != 1 || toks[0].Tok != TOK_LITCHAR || toks[0].CVal != r {
+			t.Errorf("CharLiteral(%U) = %s, which reads back as %v", r, src, toks)
+		}
+	}
+}
+
+func TestStringEscapes(t *testing.T) {
+	tests := []struct{ src, want string }{
+		{`"abc"`, "abc"},
+		{`""`, ""},
+		{`"a"`, "a"},
+		{`"\"q\""`, `"q"`},
+		{`"tab\there"`, "tab\there"},
+		{`"\101\x42C"`, "ABC"},
+		{`"\U0001F600!"`, "\U0001F600!"},
+		{`"\\"`, `\`},
+	}
+	for _, tt := range tests {
+		toks := lexAll(tt.src)
+		if len(toks) != 1 || toks[0].Tok != TOK_LITSTR {
+			t.Errorf("%s lexed as %v; want one TOK_LITSTR", tt.src, toks)
+			continue
+		}
+		if toks[0].Value != tt.want {
+			t.Errorf("%s = %q; want %q", tt.src, toks[0].Value, tt.want)
+		}
+	}
+
+	/* the token after a string starts where the string ends. */
+	toks := lexAll(`"\x41" 5`)
+	if len(toks) != 2 || toks[1].Tok != TOK_LITINT || toks[1].IVal != 5 {
+		t.Errorf(`"\x41" 5 lexed as %v`, toks)
+	}
+}
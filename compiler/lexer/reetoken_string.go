@@ -31,56 +31,81 @@ func _() {
 	_ = x[SYM_EMPTY-20]
 	_ = x[TOK_SYMBOL-21]
 	_ = x[TOK_PERIOD-22]
-	_ = x[KEY_UNDEF-23]
-	_ = x[KEY_TYPE-24]
-	_ = x[KEY_LET-25]
-	_ = x[KEY_LETREC-26]
-	_ = x[KEY_IF-27]
-	_ = x[KEY_DEFINE-28]
-	_ = x[KEY_COND-29]
-	_ = x[KEY_MATCH-30]
-	_ = x[KEY_ELSE-31]
-	_ = x[KEY_LAMBDA-32]
-	_ = x[OP_UNDEF-33]
-	_ = x[OP_ADD-34]
-	_ = x[OP_SUB-35]
-	_ = x[OP_MUL-36]
-	_ = x[OP_DIV-37]
-	_ = x[OP_ZERO-38]
-	_ = x[OP_ABS-39]
-	_ = x[OP_GT-40]
-	_ = x[OP_GTEQ-41]
-	_ = x[OP_LTEQ-42]
-	_ = x[OP_LT-43]
-	_ = x[OP_INC-44]
-	_ = x[OP_DEC-45]
-	_ = x[OP_EQ-46]
-	_ = x[OP_NEQ-47]
-	_ = x[OP_PRINT-48]
-	_ = x[OP_BOX-49]
-	_ = x[OP_UNBOX-50]
-	_ = x[OP_CONS-51]
-	_ = x[OP_CAR-52]
-	_ = x[OP_CDR-53]
-	_ = x[OP_QUOTE-54]
-	_ = x[OP_QUASIQUOTE-55]
-	_ = x[OP_UNQUOTE-56]
-	_ = x[OP_UNQUOTESPLICE-57]
-	_ = x[OP_CHECKTYPE-58]
-	_ = x[OP_MOD-59]
-	_ = x[OP_NOT-60]
-	_ = x[OP_BITAND-61]
-	_ = x[OP_BITOR-62]
-	_ = x[OP_BITXOR-63]
-	_ = x[OP_BITSHL-64]
-	_ = x[OP_BITSHR-65]
-	_ = x[OP_QUESTION-66]
-	_ = x[TOK_EOF-67]
+	_ = x[TOK_LVECTOR-23]
+	_ = x[TOK_LBRACE-24]
+	_ = x[TOK_RBRACE-25]
+	_ = x[KEY_UNDEF-26]
+	_ = x[KEY_TYPE-27]
+	_ = x[KEY_LET-28]
+	_ = x[KEY_LETREC-29]
+	_ = x[KEY_IF-30]
+	_ = x[KEY_DEFINE-31]
+	_ = x[KEY_COND-32]
+	_ = x[KEY_MATCH-33]
+	_ = x[KEY_ELSE-34]
+	_ = x[KEY_LAMBDA-35]
+	_ = x[KEY_THE-36]
+	_ = x[KEY_DECLARE-37]
+	_ = x[OP_UNDEF-38]
+	_ = x[OP_ADD-39]
+	_ = x[OP_SUB-40]
+	_ = x[OP_MUL-41]
+	_ = x[OP_DIV-42]
+	_ = x[OP_ZERO-43]
+	_ = x[OP_ABS-44]
+	_ = x[OP_GT-45]
+	_ = x[OP_GTEQ-46]
+	_ = x[OP_LTEQ-47]
+	_ = x[OP_LT-48]
+	_ = x[OP_INC-49]
+	_ = x[OP_DEC-50]
+	_ = x[OP_EQ-51]
+	_ = x[OP_NEQ-52]
+	_ = x[OP_PRINT-53]
+	_ = x[OP_BOX-54]
+	_ = x[OP_UNBOX-55]
+	_ = x[OP_CONS-56]
+	_ = x[OP_CAR-57]
+	_ = x[OP_CDR-58]
+	_ = x[OP_QUOTE-59]
+	_ = x[OP_QUASIQUOTE-60]
+	_ = x[OP_UNQUOTE-61]
+	_ = x[OP_UNQUOTESPLICE-62]
+	_ = x[OP_CHECKTYPE-63]
+	_ = x[OP_MOD-64]
+	_ = x[OP_NOT-65]
+	_ = x[OP_BITAND-66]
+	_ = x[OP_BITOR-67]
+	_ = x[OP_BITXOR-68]
+	_ = x[OP_BITSHL-69]
+	_ = x[OP_BITSHR-70]
+	_ = x[OP_QUESTION-71]
+	_ = x[OP_STRLEN-72]
+	_ = x[OP_STRREF-73]
+	_ = x[OP_SUBSTR-74]
+	_ = x[OP_STRAPPEND-75]
+	_ = x[OP_STRSPLIT-76]
+	_ = x[OP_STRJOIN-77]
+	_ = x[OP_STRSEARCH-78]
+	_ = x[OP_STRUPCASE-79]
+	_ = x[OP_STRDOWNCASE-80]
+	_ = x[OP_CHARUPCASE-81]
+	_ = x[OP_CHARDOWNCASE-82]
+	_ = x[OP_CHARALPHA-83]
+	_ = x[OP_CHARNUMERIC-84]
+	_ = x[OP_CHARSPACE-85]
+	_ = x[OP_CHARUPPER-86]
+	_ = x[OP_CHARLOWER-87]
+	_ = x[OP_NUMTOSTR-88]
+	_ = x[OP_STRTONUM-89]
+	_ = x[OP_CHARTOINT-90]
+	_ = x[OP_INTTOCHAR-91]
+	_ = x[TOK_EOF-92]
 }
 
-const _ReeToken_name = "TOK_UNDEFTOK_SHEBANGTOK_LPARENTOK_RPARENTOK_LITINTTOK_LITNUMTOK_LITSTRTOK_IDENTTOK_KEYWORDTOK_KEYOPTOK_EMPTYTOK_TRUETOK_FALSETOK_LITCHARTOK_SUPRESSSYM_LITCHARSYM_LITINTSYM_LITSTRSYM_TRUESYM_FALSESYM_EMPTYTOK_SYMBOLTOK_PERIODKEY_UNDEFKEY_TYPEKEY_LETKEY_LETRECKEY_IFKEY_DEFINEKEY_CONDKEY_MATCHKEY_ELSEKEY_LAMBDAOP_UNDEFOP_ADDOP_SUBOP_MULOP_DIVOP_ZEROOP_ABSOP_GTOP_GTEQOP_LTEQOP_LTOP_INCOP_DECOP_EQOP_NEQOP_PRINTOP_BOXOP_UNBOXOP_CONSOP_CAROP_CDROP_QUOTEOP_QUASIQUOTEOP_UNQUOTEOP_UNQUOTESPLICEOP_CHECKTYPEOP_MODOP_NOTOP_BITANDOP_BITOROP_BITXOROP_BITSHLOP_BITSHROP_QUESTIONTOK_EOF"
+const _ReeToken_name = "TOK_UNDEFTOK_SHEBANGTOK_LPARENTOK_RPARENTOK_LITINTTOK_LITNUMTOK_LITSTRTOK_IDENTTOK_KEYWORDTOK_KEYOPTOK_EMPTYTOK_TRUETOK_FALSETOK_LITCHARTOK_SUPRESSSYM_LITCHARSYM_LITINTSYM_LITSTRSYM_TRUESYM_FALSESYM_EMPTYTOK_SYMBOLTOK_PERIODTOK_LVECTORTOK_LBRACETOK_RBRACEKEY_UNDEFKEY_TYPEKEY_LETKEY_LETRECKEY_IFKEY_DEFINEKEY_CONDKEY_MATCHKEY_ELSEKEY_LAMBDAKEY_THEKEY_DECLAREOP_UNDEFOP_ADDOP_SUBOP_MULOP_DIVOP_ZEROOP_ABSOP_GTOP_GTEQOP_LTEQOP_LTOP_INCOP_DECOP_EQOP_NEQOP_PRINTOP_BOXOP_UNBOXOP_CONSOP_CAROP_CDROP_QUOTEOP_QUASIQUOTEOP_UNQUOTEOP_UNQUOTESPLICEOP_CHECKTYPEOP_MODOP_NOTOP_BITANDOP_BITOROP_BITXOROP_BITSHLOP_BITSHROP_QUESTIONOP_STRLENOP_STRREFOP_SUBSTROP_STRAPPENDOP_STRSPLITOP_STRJOINOP_STRSEARCHOP_STRUPCASEOP_STRDOWNCASEOP_CHARUPCASEOP_CHARDOWNCASEOP_CHARALPHAOP_CHARNUMERICOP_CHARSPACEOP_CHARUPPEROP_CHARLOWEROP_NUMTOSTROP_STRTONUMOP_CHARTOINTOP_INTTOCHARTOK_EOF"
 
-var _ReeToken_index = [...]uint16{0, 9, 20, 30, 40, 50, 60, 70, 79, 90, 99, 108, 116, 125, 136, 147, 158, 168, 178, 186, 195, 204, 214, 224, 233, 241, 248, 258, 264, 274, 282, 291, 299, 309, 317, 323, 329, 335, 341, 348, 354, 359, 366, 373, 378, 384, 390, 395, 401, 409, 415, 423, 430, 436, 442, 450, 463, 473, 489, 501, 507, 513, 522, 530, 539, 548, 557, 568, 575}
+var _ReeToken_index = [...]uint16{0, 9, 20, 30, 40, 50, 60, 70, 79, 90, 99, 108, 116, 125, 136, 147, 158, 168, 178, 186, 195, 204, 214, 224, 235, 245, 255, 264, 272, 279, 289, 295, 305, 313, 322, 330, 340, 347, 358, 366, 372, 378, 384, 390, 397, 403, 408, 415, 422, 427, 433, 439, 444, 450, 458, 464, 472, 479, 485, 491, 499, 512, 522, 538, 550, 556, 562, 571, 579, 588, 597, 606, 617, 626, 635, 644, 656, 667, 677, 689, 701, 715, 728, 743, 755, 769, 781, 793, 805, 816, 827, 839, 851, 858}
 
 func (i ReeToken) String() string {
 	if i >= ReeToken(len(_ReeToken_index)-1) {
@@ -28,7 +28,7 @@ func main() {
 		} else if l.Tok.Tok == lexer.TOK_LITINT {
 			fmt.Printf("[%4d:%4d] %18s %d\n", l.Tok.L, l.Tok.C, l.Tok.Tok.String(), l.Tok.IVal)
 		} else if l.Tok.Tok == lexer.TOK_LITCHAR {
-			fmt.Printf("[%4d:%4d] %18s %c\n", l.Tok.L, l.Tok.C, l.Tok.Tok.String(), l.Tok.CVal)
+			fmt.Printf("[%4d:%4d] %18s %s\n", l.Tok.L, l.Tok.C, l.Tok.Tok.String(), lexer.CharLiteral(l.Tok.CVal))
 		} else {
 			fmt.Printf("[%4d:%4d] %18s %s\n", l.Tok.L, l.Tok.C, l.Tok.Tok.String(), l.Tok.Value)
 		}
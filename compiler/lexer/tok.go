@@ -36,6 +36,9 @@ const (
 	SYM_EMPTY
 	TOK_SYMBOL
 	TOK_PERIOD
+	TOK_LVECTOR
+	TOK_LBRACE
+	TOK_RBRACE
 
 	KEY_UNDEF
 	KEY_TYPE
@@ -47,6 +50,8 @@ const (
 	KEY_MATCH
 	KEY_ELSE
 	KEY_LAMBDA
+	KEY_THE
+	KEY_DECLARE
 
 	OP_UNDEF
 	OP_ADD
@@ -82,6 +87,26 @@ const (
 	OP_BITSHL
 	OP_BITSHR
 	OP_QUESTION
+	OP_STRLEN
+	OP_STRREF
+	OP_SUBSTR
+	OP_STRAPPEND
+	OP_STRSPLIT
+	OP_STRJOIN
+	OP_STRSEARCH
+	OP_STRUPCASE
+	OP_STRDOWNCASE
+	OP_CHARUPCASE
+	OP_CHARDOWNCASE
+	OP_CHARALPHA
+	OP_CHARNUMERIC
+	OP_CHARSPACE
+	OP_CHARUPPER
+	OP_CHARLOWER
+	OP_NUMTOSTR
+	OP_STRTONUM
+	OP_CHARTOINT
+	OP_INTTOCHAR
 
 	TOK_EOF
 )
@@ -97,4 +122,75 @@ var keywords map[string]ReeToken = map[string]ReeToken{
 	"lambda": KEY_LAMBDA,
 	"λ":      KEY_LAMBDA,
 	"match":  KEY_MATCH,
+	"the":    KEY_THE,
+	":":      KEY_DECLARE,
+}
+
+/* named builtin operators; the symbolic ones (+, <=, ...) are lexed directly. */
+var builtins map[string]ReeToken = map[string]ReeToken{
+	"zero?":       OP_ZERO,
+	"abs":         OP_ABS,
+	"add1":        OP_INC,
+	"sub1":        OP_DEC,
+	"print":       OP_PRINT,
+	"box":         OP_BOX,
+	"unbox":       OP_UNBOX,
+	"cons":        OP_CONS,
+	"car":         OP_CAR,
+	"cdr":         OP_CDR,
+	"modulo":      OP_MOD,
+	"not":         OP_NOT,
+	"bitwise-and": OP_BITAND,
+	"bitwise-or":  OP_BITOR,
+	"bitwise-xor": OP_BITXOR,
+	"shift-left":  OP_BITSHL,
+	"shift-right": OP_BITSHR,
+
+	"string-length":    OP_STRLEN,
+	"string-ref":       OP_STRREF,
+	"substring":        OP_SUBSTR,
+	"string-append":    OP_STRAPPEND,
+	"string-split":     OP_STRSPLIT,
+	"string-join":      OP_STRJOIN,
+	"string-search":    OP_STRSEARCH,
+	"string-upcase":    OP_STRUPCASE,
+	"string-downcase":  OP_STRDOWNCASE,
+	"char-upcase":      OP_CHARUPCASE,
+	"char-downcase":    OP_CHARDOWNCASE,
+	"char-alphabetic?": OP_CHARALPHA,
+	"char-numeric?":    OP_CHARNUMERIC,
+	"char-whitespace?": OP_CHARSPACE,
+	"char-upper-case?": OP_CHARUPPER,
+	"char-lower-case?": OP_CHARLOWER,
+	"number->string":   OP_NUMTOSTR,
+	"string->number":   OP_STRTONUM,
+	"char->integer":    OP_CHARTOINT,
+	"integer->char":    OP_INTTOCHAR,
+}
+
+/* R7RS character names, plus the common #\nul spelling of #\null. */
+var charnames map[string]rune = map[string]rune{
+	"alarm":     '\a',
+	"backspace": '\b',
+	"delete":    0x7f,
+	"escape":    0x1b,
+	"newline":   '\n',
+	"null":      0,
+	"nul":       0,
+	"return":    '\r',
+	"space":     ' ',
+	"tab":       '\t',
+}
+
+/* canonical names used when printing characters back out. */
+var runenames map[rune]string = map[rune]string{
+	'\a': "alarm",
+	'\b': "backspace",
+	0x7f: "delete",
+	0x1b: "escape",
+	'\n': "newline",
+	0:    "null",
+	'\r': "return",
+	' ':  "space",
+	'\t': "tab",
 }
@@ -0,0 +1,187 @@
+package optimize
+
+import (
+	"math"
+
+	. "github.com/ReewassSquared/ReeCurse/compiler/lexer"
+	. "github.com/ReewassSquared/ReeCurse/compiler/parser"
+)
+
+/**
+ * Fold evaluates builtin operators whose operands are literals, bottom up,
+ * and returns the rewritten tree. An operation is only folded when its
+ * result is fully defined: overflowing arithmetic, division or modulo by
+ * zero and out-of-range shifts are left for the program to perform, so
+ * folding never changes what a program does.
+ *
+ * TODO: simplify if/cond with constant tests and propagate let-bound
+ * constants once the parser builds NODE_IF, NODE_COND and NODE_LET.
+ */
+func Fold(node *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	node.Left = Fold(node.Left)
+	node.Right = Fold(node.Right)
+	for i := range node.Nodes {
+		node.Nodes[i] = Fold(node.Nodes[i])
+	}
+
+	switch node.Ntype {
+	case NODE_UNARY:
+		return foldUnary(node)
+	case NODE_BINARY:
+		return foldBinary(node)
+	}
+	return node
+}
+
+func foldUnary(node *Node) *Node {
+	x := node.Left
+	if x == nil {
+		return node
+	}
+	if x.Ntype == NODE_BOOLEAN && node.Op == OP_NOT {
+		return boolLit(node, !x.BVal)
+	}
+	if x.Ntype != NODE_INTEGER {
+		return node
+	}
+	switch node.Op {
+	case OP_ZERO:
+		return boolLit(node, x.IVal == 0)
+	case OP_ABS:
+		if x.IVal == math.MinInt64 {
+			break
+		}
+		if x.IVal < 0 {
+			return intLit(node, -x.IVal)
+		}
+		return intLit(node, x.IVal)
+	case OP_INC:
+		if v, ok := add(x.IVal, 1); ok {
+			return intLit(node, v)
+		}
+	case OP_DEC:
+		if v, ok := sub(x.IVal, 1); ok {
+			return intLit(node, v)
+		}
+	}
+	return node
+}
+
+func foldBinary(node *Node) *Node {
+	x, y := node.Left, node.Right
+	if x == nil || y == nil {
+		return node
+	}
+
+	if node.Op == OP_EQ || node.Op == OP_NEQ {
+		if eq, ok := literalEqual(x, y); ok {
+			return boolLit(node, eq == (node.Op == OP_EQ))
+		}
+		return node
+	}
+
+	if x.Ntype != NODE_INTEGER || y.Ntype != NODE_INTEGER {
+		return node
+	}
+	a, b := x.IVal, y.IVal
+	switch node.Op {
+	case OP_ADD:
+		if v, ok := add(a, b); ok {
+			return intLit(node, v)
+		}
+	case OP_SUB:
+		if v, ok := sub(a, b); ok {
+			return intLit(node, v)
+		}
+	case OP_MUL:
+		if v, ok := mul(a, b); ok {
+			return intLit(node, v)
+		}
+	case OP_DIV:
+		if b != 0 && !(a == math.MinInt64 && b == -1) {
+			return intLit(node, a/b)
+		}
+	case OP_MOD:
+		/* modulo takes the sign of the divisor */
+		if b != 0 && !(a == math.MinInt64 && b == -1) {
+			m := a % b
+			if m != 0 && (m < 0) != (b < 0) {
+				m += b
+			}
+			return intLit(node, m)
+		}
+	case OP_GT:
+		return boolLit(node, a > b)
+	case OP_GTEQ:
+		return boolLit(node, a >= b)
+	case OP_LTEQ:
+		return boolLit(node, a <= b)
+	case OP_LT:
+		return boolLit(node, a < b)
+	case OP_BITAND:
+		return intLit(node, a&b)
+	case OP_BITOR:
+		return intLit(node, a|b)
+	case OP_BITXOR:
+		return intLit(node, a^b)
+	case OP_BITSHL:
+		/* only fold shifts that lose no bits */
+		if b >= 0 && b < 64 && (a<<uint(b))>>uint(b) == a {
+			return intLit(node, a<<uint(b))
+		}
+	case OP_BITSHR:
+		if b >= 0 && b < 64 {
+			return intLit(node, a>>uint(b))
+		}
+	}
+	return node
+}
+
+/* literalEqual compares two immediate literals of the same kind; ok is false otherwise. */
+func literalEqual(x, y *Node) (eq bool, ok bool) {
+	if x.Ntype != y.Ntype {
+		return false, false
+	}
+	switch x.Ntype {
+	case NODE_INTEGER:
+		return x.IVal == y.IVal, true
+	case NODE_BOOLEAN:
+		return x.BVal == y.BVal, true
+	case NODE_CHAR:
+		return x.CVal == y.CVal, true
+	}
+	return false, false
+}
+
+func add(a, b int64) (int64, bool) {
+	c := a + b
+	return c, (c > a) == (b > 0)
+}
+
+func sub(a, b int64) (int64, bool) {
+	c := a - b
+	return c, (c < a) == (b > 0)
+}
+
+func mul(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) || c/b != a {
+		return c, false
+	}
+	return c, true
+}
+
+/* the folded literal keeps the position and type of the node it replaces. */
+func intLit(node *Node, v int64) *Node {
+	return &Node{L: node.L, C: node.C, Ntype: NODE_INTEGER, Etype: node.Etype, IVal: v}
+}
+
+func boolLit(node *Node, v bool) *Node {
+	return &Node{L: node.L, C: node.C, Ntype: NODE_BOOLEAN, Etype: node.Etype, BVal: v}
+}
@@ -0,0 +1,110 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/ReewassSquared/ReeCurse/compiler/lexer"
+	. "github.com/ReewassSquared/ReeCurse/compiler/parser"
+)
+
+func TestOverflowChecks(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(a, b int64) (int64, bool)
+		a, b int64
+		want int64
+		ok   bool
+	}{
+		{"add", add, math.MaxInt64, 0, math.MaxInt64, true},
+		{"add", add, math.MaxInt64, 1, 0, false},
+		{"add", add, math.MinInt64, -1, 0, false},
+		{"add", add, math.MinInt64, math.MaxInt64, -1, true},
+		{"sub", sub, math.MinInt64, 0, math.MinInt64, true},
+		{"sub", sub, math.MinInt64, 1, 0, false},
+		{"sub", sub, math.MaxInt64, -1, 0, false},
+		{"sub", sub, 0, math.MinInt64, 0, false},
+		{"sub", sub, -1, math.MinInt64, math.MaxInt64, true},
+		{"mul", mul, math.MinInt64, 1, math.MinInt64, true},
+		{"mul", mul, math.MinInt64, -1, 0, false},
+		{"mul", mul, -1, math.MinInt64, 0, false},
+		{"mul", mul, math.MaxInt64, 2, 0, false},
+		{"mul", mul, math.MaxInt64, -1, -math.MaxInt64, true},
+		{"mul", mul, 1 << 31, 1 << 31, 1 << 62, true},
+		{"mul", mul, 1 << 32, 1 << 31, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.f(tt.a, tt.b)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("%s(%d, %d) = %d, %v; want %d, %v", tt.name, tt.a, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func intNode(v int64) *Node {
+	return &Node{Ntype: NODE_INTEGER, IVal: v}
+}
+
+func binary(op ReeToken, a, b int64) *Node {
+	return &Node{Ntype: NODE_BINARY, Op: op, Left: intNode(a), Right: intNode(b)}
+}
+
+func TestFoldBinary(t *testing.T) {
+	tests := []struct {
+		op     ReeToken
+		a, b   int64
+		folded bool
+		want   int64
+	}{
+		{OP_ADD, 24, 5, true, 29},
+		{OP_ADD, math.MaxInt64, 1, false, 0},
+		{OP_SUB, math.MinInt64, 1, false, 0},
+		{OP_MUL, math.MinInt64, -1, false, 0},
+		{OP_DIV, 7, 2, true, 3},
+		{OP_DIV, -7, 2, true, -3},
+		{OP_DIV, 1, 0, false, 0},
+		{OP_DIV, math.MinInt64, -1, false, 0},
+		{OP_MOD, 7, 3, true, 1},
+		{OP_MOD, -7, 3, true, 2},
+		{OP_MOD, 7, -3, true, -2},
+		{OP_MOD, -7, -3, true, -1},
+		{OP_MOD, 6, -3, true, 0},
+		{OP_MOD, 1, 0, false, 0},
+		{OP_MOD, math.MinInt64, -1, false, 0},
+		{OP_BITSHL, 1, 62, true, 1 << 62},
+		{OP_BITSHL, 1, 63, false, 0},
+		{OP_BITSHL, 3, 62, false, 0},
+		{OP_BITSHL, -1, 63, true, math.MinInt64},
+		{OP_BITSHL, 1, 64, false, 0},
+		{OP_BITSHL, 1, -1, false, 0},
+		{OP_BITSHR, -8, 1, true, -4},
+		{OP_BITSHR, 1, 64, false, 0},
+	}
+	for _, tt := range tests {
+		got := Fold(binary(tt.op, tt.a, tt.b))
+		if !tt.folded {
+			if got.Ntype != NODE_BINARY {
+				t.Errorf("(%s %d %d) folded to %d; want it left alone", tt.op, tt.a, tt.b, got.IVal)
+			}
+			continue
+		}
+		if got.Ntype != NODE_INTEGER || got.IVal != tt.want {
+			t.Errorf("(%s %d %d) = %s %d; want %d", tt.op, tt.a, tt.b, got.Ntype, got.IVal, tt.want)
+		}
+	}
+}
+
+func TestFoldUnary(t *testing.T) {
+	abs := Fold(&Node{Ntype: NODE_UNARY, Op: OP_ABS, Left: intNode(math.MinInt64)})
+	if abs.Ntype != NODE_UNARY {
+		t.Errorf("(abs MinInt64) folded; want it left alone")
+	}
+	inc := Fold(&Node{Ntype: NODE_UNARY, Op: OP_INC, Left: intNode(math.MaxInt64)})
+	if inc.Ntype != NODE_UNARY {
+		t.Errorf("(add1 MaxInt64) folded; want it left alone")
+	}
+	zero := Fold(&Node{Ntype: NODE_UNARY, Op: OP_ZERO, Left: binary(OP_SUB, 5, 5)})
+	if zero.Ntype != NODE_BOOLEAN || !zero.BVal {
+		t.Errorf("(zero? (- 5 5)) = %s %v; want #t", zero.Ntype, zero.BVal)
+	}
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"fmt"
+
+	. "github.com/ReewassSquared/ReeCurse/compiler/lexer"
+)
+
+/**
+ * declaration = (: name TYPE)
+ * Later references to name have the declared type.
+ */
+func (p *ReeParser) declare() {
+	if p.missing("name in declaration") {
+		return
+	}
+	name := p.Tok
+	if name.Tok != TOK_IDENT {
+		p.Errorf(fmt.Sprintf("unexpected %s; wanted %s", name.Tok.String(), TOK_IDENT.String()))
+		p.skip()
+		return
+	}
+	if p.missing("type in declaration") {
+		return
+	}
+	typ := p.typeexpr()
+	p.close()
+	if prev, ok := p.decls[name.Value]; ok && !Equal(prev, typ) {
+		p.Errorf(fmt.Sprintf("%s already declared as %s", name.Value, prev))
+		return
+	}
+	p.decls[name.Value] = typ
+}
+
+/**
+ * the_expr = (the TYPE EXPR)
+ * The expression must be usable as TYPE; see coerce.
+ */
+func (p *ReeParser) the() *Node {
+	if p.missing("type in the") {
+		return nil
+	}
+	typ := p.typeexpr()
+	if p.missing("expression in the") {
+		return nil
+	}
+	expr := p.expr()
+	p.close()
+	if expr == nil {
+		return nil
+	}
+	node := p.coerce(expr, typ, "the")
+	/* the annotated type replaces a more precise one, or a less precise one it was trusted or checked against. */
+	if node == expr && (Subtype(expr.Etype, typ) || Subtype(typ, expr.Etype)) {
+		node.Etype = typ
+	}
+	return node
+}
+
+/**
+ * missing moves to the next token and reports what is missing if that
+ * token ends the form, leaving it as the current token.
+ */
+func (p *ReeParser) missing(what string) bool {
+	if p.Next(); p.got(TOK_RPAREN) || p.got(TOK_EOF) {
+		p.Errorf("missing " + what)
+		return true
+	}
+	return false
+}
+
+/**
+ * coerce checks that node can be used where typ is expected and returns
+ * the node to use there. A node whose static type is only less precise
+ * than typ (it involves any) is accepted: Gradual mode wraps it in an
+ * OP_CHECKTYPE node that tests the value at runtime and blames node's
+ * position on failure, while outside gradual mode it passes unchecked.
+ */
+func (p *ReeParser) coerce(node *Node, typ *ReeType, what string) *Node {
+	if node == nil || Subtype(node.Etype, typ) {
+		return node
+	}
+	if Subtype(typ, node.Etype) {
+		if !p.Gradual {
+			return node
+		}
+		check := &Node{L: node.L, C: node.C, Ntype: NODE_UNARY, Op: OP_CHECKTYPE, Left: node, Etype: typ}
+		check.Blame = fmt.Sprintf("[%d:%d] %s: expected %s", node.L+1, node.C+1, what, typ)
+		return check
+	}
+	p.errorAt(node, fmt.Sprintf("%s: expected %s, got %s", what, typ, node.Etype))
+	return node
+}
+
+/* errorAt reports msg at node's position rather than the lexer's. */
+func (p *ReeParser) errorAt(node *Node, msg string) {
+	fmt.Printf("[%d:%d] %s\n", node.L+1, node.C+1, msg)
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/ReewassSquared/ReeCurse/compiler/lexer"
+)
+
+func parseProgram(src string, gradual bool) []*Node {
+	p := &ReeParser{Gradual: gradual}
+	p.Parse(strings.NewReader(src))
+	return p.Node.Nodes
+}
+
+func TestMissingAnnotationParts(t *testing.T) {
+	/* a malformed form must not swallow the rest of the file. */
+	nodes := parseProgram("(the int) (: x) (:) 5 6 (+ 1 2)", false)
+	if len(nodes) != 3 {
+		t.Fatalf("parsed %d top-level forms; want 3", len(nodes))
+	}
+	if nodes[0].Ntype != NODE_INTEGER || nodes[0].IVal != 5 {
+		t.Errorf("first form is %s; want the literal 5", nodes[0].Ntype)
+	}
+}
+
+func TestCoerce(t *testing.T) {
+	/* outside gradual mode, types that only involve any pass unchecked. */
+	nodes := parseProgram("(the (vector int) #(1 y)) (the int y)", false)
+	if len(nodes) != 2 {
+		t.Fatalf("parsed %d top-level forms; want 2", len(nodes))
+	}
+	for _, node := range nodes {
+		if node.Op == OP_CHECKTYPE {
+			t.Errorf("check inserted outside gradual mode")
+		}
+	}
+	if got := nodes[0].Etype.String(); got != "(vector int)" {
+		t.Errorf("annotated vector has type %s; want (vector int)", got)
+	}
+
+	nodes = parseProgram("(the (vector int) #(1 y)) (not w) (the int 5)", true)
+	if len(nodes) != 3 {
+		t.Fatalf("parsed %d top-level forms; want 3", len(nodes))
+	}
+	if nodes[0].Op != OP_CHECKTYPE || nodes[0].Blame == "" || nodes[0].Etype.String() != "(vector int)" {
+		t.Errorf("the on (vector any) was not checked at runtime")
+	}
+	if arg := nodes[1].Left; arg.Op != OP_CHECKTYPE || !strings.Contains(arg.Blame, "argument 1 of not") {
+		t.Errorf("operand of unknown type was not checked; blame %q", arg.Blame)
+	}
+	if nodes[2].Ntype != NODE_INTEGER {
+		t.Errorf("check inserted around a statically typed expression")
+	}
+
+	/* an annotation may also forget precision. */
+	nodes = parseProgram("(the any 5) (the (list any) (the (list int) y))", false)
+	if len(nodes) != 2 || nodes[0].Etype.String() != "any" || nodes[1].Etype.String() != "(list any)" {
+		t.Errorf("widening annotations did not change the expression's type")
+	}
+}
@@ -17,6 +17,14 @@ type Node struct {
 	Etype   *ReeType
 	Supress bool
 
+	Value string //strings and identifiers
+	IVal  int64  //integers
+	CVal  rune   //characters
+	BVal  bool   //booleans
+
+	/* for OP_CHECKTYPE: the position and reason reported if the check fails. */
+	Blame string
+
 	Nodes []*Node
 
 	/* defines and other non-return expressions can be in here. */
@@ -30,16 +38,11 @@ type TypeVal uint
 /**
  * Types.
  * Typing can be native, tree-like or even parametric, AND recursive.
- * Parametric types have
- *
- *
- *
- *
- *
- *
- *
- *
- *
+ * Parametric types have their type arguments in Params: the element of a
+ * list, box or vector, the key and value of a map, or the argument types
+ * followed by the result type of a function. Type variables are TYPE_VAR
+ * and keep the name they were written with. A recursive type simply
+ * points back at itself; see typesyntax.go for how they are written.
  */
 type ReeType struct {
 	Val    TypeVal
@@ -49,6 +52,7 @@ type ReeType struct {
 	Params []*ReeType //
 }
 
+/* native types only; each compilation copies them into its own TypeRegistry. */
 var typemap map[string]*ReeType = map[string]*ReeType{}
 
 const (
@@ -61,6 +65,10 @@ const (
 	TYPE_BOX
 	TYPE_CONS
 	TYPE_LIST
+	TYPE_VECTOR
+	TYPE_MAP
+	TYPE_FUNC
+	TYPE_VAR
 	TYPE_CUSTOM
 )
 
@@ -68,9 +76,11 @@ const (
 	NODE_UNDEF Nodetype = iota
 	NODE_INTEGER
 	NODE_STRING
+	NODE_CHAR
 	NODE_BOOLEAN
 	NODE_UNARY
 	NODE_BINARY
+	NODE_NARY
 	NODE_IF
 	NODE_COND
 	NODE_LET
@@ -82,6 +92,9 @@ const (
 	NODE_QUOTE
 	NODE_MATCH
 	NODE_MATCHCLAUSE
+	NODE_VECTOR
+	NODE_MAP
+	NODE_PROGRAM
 )
 
 func addNativeType(typ TypeVal, name string) {
@@ -92,4 +105,6 @@ func init() {
 	/* populate the typemap with builtin types */
 	addNativeType(TYPE_INT, "int")
 	addNativeType(TYPE_BOOLEAN, "bool")
+	addNativeType(TYPE_STRING, "string")
+	addNativeType(TYPE_CHAR, "char")
 }
@@ -11,25 +11,30 @@ func _() {
 	_ = x[NODE_UNDEF-0]
 	_ = x[NODE_INTEGER-1]
 	_ = x[NODE_STRING-2]
-	_ = x[NODE_BOOLEAN-3]
-	_ = x[NODE_UNARY-4]
-	_ = x[NODE_BINARY-5]
-	_ = x[NODE_IF-6]
-	_ = x[NODE_COND-7]
-	_ = x[NODE_LET-8]
-	_ = x[NODE_CLAUSE-9]
-	_ = x[NODE_BIND-10]
-	_ = x[NODE_VARIABLE-11]
-	_ = x[NODE_EMPTY-12]
-	_ = x[NODE_DEFINE-13]
-	_ = x[NODE_QUOTE-14]
-	_ = x[NODE_MATCH-15]
-	_ = x[NODE_MATCHCLAUSE-16]
+	_ = x[NODE_CHAR-3]
+	_ = x[NODE_BOOLEAN-4]
+	_ = x[NODE_UNARY-5]
+	_ = x[NODE_BINARY-6]
+	_ = x[NODE_NARY-7]
+	_ = x[NODE_IF-8]
+	_ = x[NODE_COND-9]
+	_ = x[NODE_LET-10]
+	_ = x[NODE_CLAUSE-11]
+	_ = x[NODE_BIND-12]
+	_ = x[NODE_VARIABLE-13]
+	_ = x[NODE_EMPTY-14]
+	_ = x[NODE_DEFINE-15]
+	_ = x[NODE_QUOTE-16]
+	_ = x[NODE_MATCH-17]
+	_ = x[NODE_MATCHCLAUSE-18]
+	_ = x[NODE_VECTOR-19]
+	_ = x[NODE_MAP-20]
+	_ = x[NODE_PROGRAM-21]
 }
 
-const _Nodetype_name = "NODE_UNDEFNODE_INTEGERNODE_STRINGNODE_BOOLEANNODE_UNARYNODE_BINARYNODE_IFNODE_CONDNODE_LETNODE_CLAUSENODE_BINDNODE_VARIABLENODE_EMPTYNODE_DEFINENODE_QUOTENODE_MATCHNODE_MATCHCLAUSE"
+const _Nodetype_name = "NODE_UNDEFNODE_INTEGERNODE_STRINGNODE_CHARNODE_BOOLEANNODE_UNARYNODE_BINARYNODE_NARYNODE_IFNODE_CONDNODE_LETNODE_CLAUSENODE_BINDNODE_VARIABLENODE_EMPTYNODE_DEFINENODE_QUOTENODE_MATCHNODE_MATCHCLAUSENODE_VECTORNODE_MAPNODE_PROGRAM"
 
-var _Nodetype_index = [...]uint8{0, 10, 22, 33, 45, 55, 66, 73, 82, 90, 101, 110, 123, 133, 144, 154, 164, 180}
+var _Nodetype_index = [...]uint8{0, 10, 22, 33, 42, 54, 64, 75, 84, 91, 100, 108, 119, 128, 141, 151, 162, 172, 182, 198, 209, 217, 229}
 
 func (i Nodetype) String() string {
 	if i >= Nodetype(len(_Nodetype_index)-1) {
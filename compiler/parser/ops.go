@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/ReewassSquared/ReeCurse/compiler/lexer"
+)
+
+/**
+ * Signatures of the builtin operators, written as function types. An
+ * operator takes one operand per parameter type; type variables are
+ * bound afresh by the operands of each application.
+ *
+ * Strings are sequences of characters, not bytes: lengths and indices
+ * count code points of the UTF-8 text. string-search gives the index of
+ * the first occurrence of its second operand in its first, or -1.
+ */
+var opsigs map[ReeToken]string = map[ReeToken]string{
+	OP_ADD:    "(-> int int int)",
+	OP_SUB:    "(-> int int int)",
+	OP_MUL:    "(-> int int int)",
+	OP_DIV:    "(-> int int int)",
+	OP_MOD:    "(-> int int int)",
+	OP_ZERO:   "(-> int bool)",
+	OP_ABS:    "(-> int int)",
+	OP_INC:    "(-> int int)",
+	OP_DEC:    "(-> int int)",
+	OP_GT:     "(-> int int bool)",
+	OP_GTEQ:   "(-> int int bool)",
+	OP_LTEQ:   "(-> int int bool)",
+	OP_LT:     "(-> int int bool)",
+	OP_EQ:     "(-> a b bool)",
+	OP_NEQ:    "(-> a b bool)",
+	OP_NOT:    "(-> bool bool)",
+	OP_BITAND: "(-> int int int)",
+	OP_BITOR:  "(-> int int int)",
+	OP_BITXOR: "(-> int int int)",
+	OP_BITSHL: "(-> int int int)",
+	OP_BITSHR: "(-> int int int)",
+	OP_PRINT:  "(-> a any)",
+	OP_BOX:    "(-> a (box a))",
+	OP_UNBOX:  "(-> (box a) a)",
+	OP_CONS:   "(-> a b (cons a b))",
+	OP_CAR:    "(-> (cons a b) a)",
+	OP_CDR:    "(-> (cons a b) b)",
+
+	OP_STRLEN:       "(-> string int)",
+	OP_STRREF:       "(-> string int char)",
+	OP_SUBSTR:       "(-> string int int string)",
+	OP_STRAPPEND:    "(-> string string string)",
+	OP_STRSPLIT:     "(-> string string (list string))",
+	OP_STRJOIN:      "(-> (list string) string string)",
+	OP_STRSEARCH:    "(-> string string int)",
+	OP_STRUPCASE:    "(-> string string)",
+	OP_STRDOWNCASE:  "(-> string string)",
+	OP_CHARUPCASE:   "(-> char char)",
+	OP_CHARDOWNCASE: "(-> char char)",
+	OP_CHARALPHA:    "(-> char bool)",
+	OP_CHARNUMERIC:  "(-> char bool)",
+	OP_CHARSPACE:    "(-> char bool)",
+	OP_CHARUPPER:    "(-> char bool)",
+	OP_CHARLOWER:    "(-> char bool)",
+	OP_NUMTOSTR:     "(-> int string)",
+	OP_STRTONUM:     "(-> string int)",
+	OP_CHARTOINT:    "(-> char int)",
+	OP_INTTOCHAR:    "(-> int char)",
+}
+
+/* signature returns the parsed signature of op, interned in p.Types. */
+func (p *ReeParser) signature(op ReeToken) *ReeType {
+	if sig, ok := p.sigs[op]; ok {
+		return sig
+	}
+	if p.sigs == nil {
+		p.sigs = map[ReeToken]*ReeType{}
+	}
+	sp := &ReeParser{ReeLexer: &ReeLexer{}, Types: p.Types}
+	sp.Init(strings.NewReader(opsigs[op]))
+	p.sigs[op] = sp.ParseType()
+	return p.sigs[op]
+}
+
+/**
+ * application builds the NODE_UNARY, NODE_BINARY or NODE_NARY (whose
+ * operands are in Nodes) for (op args...),
+ * where op is the current token. Arguments are checked against the
+ * parameter types of op's signature, with its type variables bound by
+ * the arguments; the result type is the signature's under those bindings.
+ */
+func (p *ReeParser) application(op Token) *Node {
+	node := p.MakeNode(NODE_UNARY)
+	node.Op = op.Tok
+	args := p.exprs(TOK_RPAREN)
+
+	sig := p.signature(op.Tok)
+	params, ret := sig.Params[:len(sig.Params)-1], sig.Params[len(sig.Params)-1]
+	if len(args) != len(params) {
+		p.Errorf(fmt.Sprintf("%s expects %d argument(s), got %d", op.Value, len(params), len(args)))
+		return nil
+	}
+
+	binds := map[*ReeType]*ReeType{}
+	for i, arg := range args {
+		match(params[i], arg.Etype, binds)
+	}
+	for i, arg := range args {
+		args[i] = p.coerce(arg, p.subst(params[i], binds), fmt.Sprintf("argument %d of %s", i+1, op.Value))
+	}
+	switch len(args) {
+	case 1:
+		node.Left = args[0]
+	case 2:
+		node.Ntype = NODE_BINARY
+		node.Left, node.Right = args[0], args[1]
+	default:
+		node.Ntype = NODE_NARY
+		node.Nodes = args
+	}
+	node.Etype = p.subst(ret, binds)
+	return node
+}
+
+/**
+ * match binds the type variables of pattern to the matching parts of typ.
+ * The first binding of a variable wins, and parts of unknown type bind
+ * nothing; mismatches are left for coerce to report.
+ */
+func match(pattern, typ *ReeType, binds map[*ReeType]*ReeType) {
+	if typ == nil || typ.Val == TYPE_UNK {
+		return
+	}
+	if pattern.Val == TYPE_VAR {
+		if _, ok := binds[pattern]; !ok {
+			binds[pattern] = typ
+		}
+		return
+	}
+	if leaftype(pattern) || pattern.Val != typ.Val || len(pattern.Params) != len(typ.Params) {
+		return
+	}
+	for i, sub := range pattern.Params {
+		match(sub, typ.Params[i], binds)
+	}
+}
+
+/* subst replaces the type variables of typ by their bindings; unbound ones become any. */
+func (p *ReeParser) subst(typ *ReeType, binds map[*ReeType]*ReeType) *ReeType {
+	if typ.Val == TYPE_VAR {
+		if bound, ok := binds[typ]; ok {
+			return bound
+		}
+		return p.Types.Intern(&ReeType{Val: TYPE_UNK})
+	}
+	if leaftype(typ) {
+		return typ
+	}
+	params := make([]*ReeType, len(typ.Params))
+	for i, sub := range typ.Params {
+		params[i] = p.subst(sub, binds)
+	}
+	return p.Types.Intern(&ReeType{Val: typ.Val, Params: params})
+}
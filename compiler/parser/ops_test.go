@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"testing"
+
+	. "github.com/ReewassSquared/ReeCurse/compiler/lexer"
+)
+
+func TestSignatures(t *testing.T) {
+	p := &ReeParser{Types: NewTypeRegistry()}
+	for op, src := range opsigs {
+		if sig := p.signature(op); sig.Val != TYPE_FUNC || len(sig.Params) < 2 {
+			t.Errorf("signature of %s is %s; want a function of at least one operand", op, src)
+		}
+	}
+}
+
+func TestApplicationTypes(t *testing.T) {
+	tests := []struct{ src, want string }{
+		{"(+ 1 2)", "int"},
+		{"(= 1 #t)", "bool"},
+		{"(box 5)", "(box int)"},
+		{"(unbox (box #\\a))", "char"},
+		{"(cons 1 #t)", "(cons int bool)"},
+		{"(car (cons 1 #t))", "int"},
+		{"(cdr (cons 1 #t))", "bool"},
+		{"(cons 1 x)", "(cons int any)"},
+		{"(unbox x)", "any"},
+		{"(string-length \"λx\")", "int"},
+		{"(string-ref \"abc\" 1)", "char"},
+		{"(substring \"abc\" 0 2)", "string"},
+		{"(string-split \"a b\" \" \")", "(list string)"},
+		{"(string-join (string-split \"a b\" \" \") \",\")", "string"},
+		{"(char-alphabetic? (char-upcase #\\a))", "bool"},
+		{"(number->string (string->number \"12\"))", "string"},
+		{"(integer->char (char->integer #\\a))", "char"},
+	}
+	for _, tt := range tests {
+		nodes := parseProgram(tt.src, false)
+		if len(nodes) != 1 {
+			t.Errorf("%s parsed as %d forms; want 1", tt.src, len(nodes))
+			continue
+		}
+		if got := nodes[0].Etype.String(); got != tt.want {
+			t.Errorf("%s has type %s; want %s", tt.src, got, tt.want)
+		}
+	}
+
+	/* operands of the wrong shape are rejected rather than typed any. */
+	for _, src := range []string{"(car 5)", "(unbox #t)", "(cdr (box 1))", "(string-upcase #\\a)"} {
+		if nodes := parseProgram(src, true); len(nodes) != 1 || nodes[0].Left.Op == OP_CHECKTYPE {
+			t.Errorf("%s was not rejected statically", src)
+		}
+	}
+
+	/* operators of more than two operands keep them in Nodes. */
+	nodes := parseProgram("(substring \"abc\" 1 (string-length \"ab\"))", false)
+	if len(nodes) != 1 || nodes[0].Ntype != NODE_NARY || len(nodes[0].Nodes) != 3 || nodes[0].Nodes[2].Op != OP_STRLEN {
+		t.Errorf("substring was not parsed as a NODE_NARY of 3 operands")
+	}
+
+	/* a precisely typed operand needs no runtime check. */
+	nodes = parseProgram("(the (box int) (box 5)) (car y)", true)
+	if len(nodes) != 2 {
+		t.Fatalf("parsed %d top-level forms; want 2", len(nodes))
+	}
+	if nodes[0].Op == OP_CHECKTYPE {
+		t.Errorf("(the (box int) (box 5)) was checked at runtime")
+	}
+	if nodes[1].Left.Op != OP_CHECKTYPE {
+		t.Errorf("operand of unknown type to car was not checked")
+	}
+}
@@ -9,7 +9,13 @@ import (
 
 type ReeParser struct {
 	*ReeLexer
-	Node *Node
+	Node  *Node
+	Types *TypeRegistry
+
+	/* if set, values of unknown type are checked at runtime where typed code expects them. */
+	Gradual bool
+	decls   map[string]*ReeType   //types given by (: name type)
+	sigs    map[ReeToken]*ReeType //parsed opsigs, see signature
 }
 
 func (p ReeParser) got(tok ReeToken) bool {
@@ -24,10 +30,22 @@ func (p ReeParser) want(tok ReeToken) {
 }
 
 func (p *ReeParser) Parse(r io.Reader) {
+	p.ReeLexer = &ReeLexer{}
 	p.Init(r)
+	if p.Types == nil {
+		p.Types = NewTypeRegistry()
+	}
+	p.decls = map[string]*ReeType{}
 
-	for !p.got(TOK_EOF) {
-		p.ParseExpr()
+	/* top-level forms are collected in order under a NODE_PROGRAM. */
+	p.Node = p.MakeNode(NODE_PROGRAM)
+	for p.Next(); !p.got(TOK_EOF); p.Next() {
+		if p.got(TOK_SHEBANG) {
+			continue
+		}
+		if node := p.expr(); node != nil {
+			p.Node.Nodes = append(p.Node.Nodes, node)
+		}
 	}
 }
 
@@ -38,18 +56,147 @@ func (p *ReeParser) Parse(r io.Reader) {
  *		| if_expr    | let_expr   		| letrec_expr
  *		| cond_expr  | match_expr
  *		| define	 | unary_expr		| binary_expr
- *		| *ary_expr
+ *		| *ary_expr  | vector_expr		| map_expr
+ *		| the_expr   | declaration
  */
-func (p *ReeParser) ParseExpr() {
-	tok := p.Next()
+func (p *ReeParser) ParseExpr() *Node {
+	p.Next()
+	return p.expr()
+}
+
+/* expr parses the expression starting at the current token. */
+func (p *ReeParser) expr() *Node {
+	tok := p.Tok
 	switch tok.Tok {
 	case TOK_LITINT:
 		node := p.MakeNode(NODE_INTEGER)
-		node.Etype = typemap["int"]
-		break
+		node.Etype = p.Types.Lookup("int")
+		node.IVal = tok.IVal
+		return node
+	case TOK_LITSTR:
+		node := p.MakeNode(NODE_STRING)
+		node.Etype = p.Types.Lookup("string")
+		node.Value = tok.Value
+		return node
+	case TOK_LITCHAR:
+		node := p.MakeNode(NODE_CHAR)
+		node.Etype = p.Types.Lookup("char")
+		node.CVal = tok.CVal
+		return node
+	case TOK_IDENT:
+		node := p.MakeNode(NODE_VARIABLE)
+		node.Value = tok.Value
+		if typ, ok := p.decls[tok.Value]; ok {
+			node.Etype = typ
+		} else {
+			node.Etype = p.Types.Intern(&ReeType{Val: TYPE_UNK})
+		}
+		return node
+	case TOK_TRUE, TOK_FALSE:
+		node := p.MakeNode(NODE_BOOLEAN)
+		node.Etype = p.Types.Lookup("bool")
+		node.BVal = tok.Tok == TOK_TRUE
+		return node
+	case TOK_LPAREN:
+		head := p.Next()
+		if _, ok := opsigs[head.Tok]; ok {
+			return p.application(*head)
+		}
+		switch head.Tok {
+		case KEY_THE:
+			return p.the()
+		case KEY_DECLARE:
+			p.declare()
+			return nil
+		}
+		p.Errorf("unimplemented")
+		p.skip()
+		return nil
+	case TOK_LVECTOR:
+		/* vector_expr = #( EXPR* ) */
+		node := p.MakeNode(NODE_VECTOR)
+		node.Nodes = p.exprs(TOK_RPAREN)
+		node.Etype = p.Types.Intern(&ReeType{Val: TYPE_VECTOR, Params: []*ReeType{p.uniform(node.Nodes, 0, 1)}})
+		return node
+	case TOK_LBRACE:
+		/* map_expr = { (EXPR EXPR)* } */
+		node := p.MakeNode(NODE_MAP)
+		node.Nodes = p.exprs(TOK_RBRACE)
+		if len(node.Nodes)%2 != 0 {
+			p.Errorf("map literal has a key without a value")
+			node.Etype = p.Types.Intern(&ReeType{Val: TYPE_UNK})
+			return node
+		}
+		node.Etype = p.Types.Intern(&ReeType{Val: TYPE_MAP, Params: []*ReeType{p.uniform(node.Nodes, 0, 2), p.uniform(node.Nodes, 1, 2)}})
+		return node
 	default:
 		p.Errorf("unimplemented")
 	}
+	return nil
+}
+
+/**
+ * exprs parses expressions up to and including the closing token.
+ * Any closing token ends the list, so a mismatched one is reported
+ * rather than read past; forms that failed to parse are left out.
+ */
+func (p *ReeParser) exprs(closer ReeToken) []*Node {
+	nodes := []*Node{}
+	for p.Next(); !p.got(TOK_RPAREN) && !p.got(TOK_RBRACE); p.Next() {
+		if p.got(TOK_EOF) {
+			break
+		}
+		if node := p.expr(); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	if !p.got(closer) {
+		p.Errorf(fmt.Sprintf("unexpected %s; wanted %s", p.Tok.Tok.String(), closer.String()))
+	}
+	return nodes
+}
+
+/* close expects the TOK_RPAREN ending the current form as the next token. */
+func (p *ReeParser) close() {
+	if p.Next(); !p.got(TOK_RPAREN) {
+		p.Errorf(fmt.Sprintf("unexpected %s; wanted %s", p.Tok.Tok.String(), TOK_RPAREN.String()))
+		p.skip()
+	}
+}
+
+/* skip discards tokens through the TOK_RPAREN closing the current list. */
+func (p *ReeParser) skip() {
+	for depth := 1; ; p.Next() {
+		switch p.Tok.Tok {
+		case TOK_LPAREN, TOK_LVECTOR, TOK_LBRACE:
+			depth++
+		case TOK_RPAREN, TOK_RBRACE:
+			depth--
+		case TOK_EOF:
+			return
+		}
+		if depth == 0 {
+			return
+		}
+	}
+}
+
+/**
+ * uniform returns the type shared by every step'th node starting at first,
+ * or an unknown type if they differ (or there are none).
+ */
+func (p *ReeParser) uniform(nodes []*Node, first, step int) *ReeType {
+	var typ *ReeType
+	for i := first; i < len(nodes); i += step {
+		if nodes[i] == nil || nodes[i].Etype == nil || (typ != nil && typ != nodes[i].Etype) {
+			return p.Types.Intern(&ReeType{Val: TYPE_UNK})
+		}
+		typ = nodes[i].Etype
+	}
+	if typ == nil {
+		return p.Types.Intern(&ReeType{Val: TYPE_UNK})
+	}
+	return typ
 }
 
 func (p *ReeParser) MakeNode(ntype Nodetype) *Node {
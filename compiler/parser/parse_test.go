@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestVectorAndMapLiterals(t *testing.T) {
+	tests := []struct {
+		src   string
+		ntype Nodetype
+		n     int
+		typ   string
+	}{
+		{"#()", NODE_VECTOR, 0, "(vector any)"},
+		{"#(1 2 3)", NODE_VECTOR, 3, "(vector int)"},
+		{`#(1 "a")`, NODE_VECTOR, 2, "(vector any)"},
+		{"#(#(1 2) #(3))", NODE_VECTOR, 2, "(vector (vector int))"},
+		{"{}", NODE_MAP, 0, "(map any any)"},
+		{`{1 "a" 2 "b"}`, NODE_MAP, 4, "(map int string)"},
+		{`{"k" #(1) "j" {#\a #t}}`, NODE_MAP, 4, "(map string any)"},
+		{"{#\\a {1 #t}}", NODE_MAP, 2, "(map char (map int bool))"},
+	}
+	for _, tt := range tests {
+		nodes := parseProgram(tt.src, false)
+		if len(nodes) != 1 {
+			t.Errorf("%s parsed to %d forms; want 1", tt.src, len(nodes))
+			continue
+		}
+		node := nodes[0]
+		if node.Ntype != tt.ntype || len(node.Nodes) != tt.n || node.Etype.String() != tt.typ {
+			t.Errorf("%s = %s with %d children of type %s; want %s with %d of type %s",
+				tt.src, node.Ntype, len(node.Nodes), node.Etype, tt.ntype, tt.n, tt.typ)
+		}
+	}
+}
+
+func TestMismatchedClosers(t *testing.T) {
+	/* the wrong closer ends the literal; what follows is a new form. */
+	nodes := parseProgram("#(1 2} 9 {1 2) (+ 1 2)", false)
+	if len(nodes) != 4 {
+		t.Fatalf("parsed %d forms; want 4", len(nodes))
+	}
+	if len(nodes[0].Nodes) != 2 || nodes[1].Ntype != NODE_INTEGER || nodes[1].IVal != 9 {
+		t.Errorf("9 was read into the vector")
+	}
+	if nodes[2].Ntype != NODE_MAP || len(nodes[2].Nodes) != 2 {
+		t.Errorf("{1 2) = %s with %d children; want a two-entry map", nodes[2].Ntype, len(nodes[2].Nodes))
+	}
+	if nodes[3].Ntype != NODE_BINARY {
+		t.Errorf("form after the mismatch = %s; want NODE_BINARY", nodes[3].Ntype)
+	}
+
+	/* forms that fail to parse leave no nil children behind. */
+	nodes = parseProgram("#(1 (foo) 2)", false)
+	for _, child := range nodes[0].Nodes {
+		if child == nil {
+			t.Errorf("nil child in vector")
+		}
+	}
+}
+
+func TestOddMap(t *testing.T) {
+	nodes := parseProgram("{1 2 3} 4", false)
+	if len(nodes) != 2 {
+		t.Fatalf("parsed %d forms; want 2", len(nodes))
+	}
+	if nodes[0].Etype.Val != TYPE_UNK {
+		t.Errorf("{1 2 3} has type %s; want any", nodes[0].Etype)
+	}
+}
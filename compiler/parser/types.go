@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+/**
+ * TypeRegistry holds the types known to a single compilation.
+ * The native types from typemap are copied in when it is created, so
+ * user types registered in one session never leak into another. Composite
+ * types are interned: two structurally identical types share one *ReeType,
+ * which lets the parser compare types by pointer.
+ *
+ * Lookups may run concurrently with each other; Register and Intern
+ * take the write lock.
+ */
+type TypeRegistry struct {
+	mu       sync.RWMutex
+	types    map[string]*ReeType //named types, native and user-defined
+	interned map[string]*ReeType //composite types by structural key
+	canon    map[*ReeType]bool   //the values of interned, never modified again
+}
+
+func NewTypeRegistry() *TypeRegistry {
+	r := &TypeRegistry{
+		types:    make(map[string]*ReeType, len(typemap)),
+		interned: map[string]*ReeType{},
+		canon:    map[*ReeType]bool{},
+	}
+	for name, typ := range typemap {
+		native := *typ
+		r.types[name] = &native
+	}
+	return r
+}
+
+/* Lookup returns the named type, or nil if no such type is registered. */
+func (r *TypeRegistry) Lookup(name string) *ReeType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.types[name]
+}
+
+/* Register adds a named user type. It returns false if the name is taken. */
+func (r *TypeRegistry) Register(typ *ReeType) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.types[typ.Name]; ok || typ.Name == "" {
+		return false
+	}
+	r.types[typ.Name] = typ
+	return true
+}
+
+/**
+ * Intern returns the canonical *ReeType structurally identical to typ.
+ * The Params and Types of typ are replaced by their canonical versions,
+ * unless typ is itself canonical: canonical types may be read by other
+ * goroutines without the lock, so they are never modified.
+ * Type variables are never merged: the a of one annotation is not the a
+ * of another.
+ */
+func (r *TypeRegistry) Intern(typ *ReeType) *ReeType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.intern(typ, map[*ReeType]bool{})
+}
+
+func (r *TypeRegistry) intern(typ *ReeType, visiting map[*ReeType]bool) *ReeType {
+	/* type variables are scoped to their annotation, so each stays distinct. */
+	if typ == nil || typ.Val == TYPE_VAR {
+		return typ
+	}
+	/* named types are nominal; this also cuts recursive types short. */
+	if typ.Name != "" {
+		if named, ok := r.types[typ.Name]; ok {
+			return named
+		}
+	}
+	if r.canon[typ] || visiting[typ] {
+		return typ
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	var key strings.Builder
+	fmt.Fprintf(&key, "%d:%s", typ.Val, typ.Name)
+	for i, sub := range typ.Params {
+		typ.Params[i] = r.intern(sub, visiting)
+		fmt.Fprintf(&key, " p%p", typ.Params[i])
+	}
+	for i, sub := range typ.Types {
+		typ.Types[i] = r.intern(sub, visiting)
+		fmt.Fprintf(&key, " t%p", typ.Types[i])
+	}
+
+	if canon, ok := r.interned[key.String()]; ok {
+		return canon
+	}
+	r.interned[key.String()] = typ
+	r.canon[typ] = true
+	return typ
+}
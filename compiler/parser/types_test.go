@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRegistryIsolation(t *testing.T) {
+	r1, r2 := NewTypeRegistry(), NewTypeRegistry()
+
+	if r1.Lookup("int") == nil || r1.Lookup("int") == r2.Lookup("int") {
+		t.Fatalf("native types should be copied into each registry")
+	}
+	if r1.Lookup("int") == typemap["int"] {
+		t.Fatalf("registry shares the native template in typemap")
+	}
+
+	point := &ReeType{Val: TYPE_CUSTOM, Name: "point"}
+	if !r1.Register(point) {
+		t.Fatalf("Register(point) failed on a fresh registry")
+	}
+	if r1.Register(&ReeType{Val: TYPE_CUSTOM, Name: "point"}) {
+		t.Errorf("Register accepted a second type named point")
+	}
+	if r1.Register(&ReeType{Val: TYPE_CUSTOM, Name: "int"}) {
+		t.Errorf("Register accepted a type shadowing int")
+	}
+	if r1.Lookup("point") != point {
+		t.Errorf("Lookup(point) did not return the registered type")
+	}
+	if r2.Lookup("point") != nil {
+		t.Errorf("point leaked into another registry")
+	}
+	if _, ok := typemap["point"]; ok {
+		t.Errorf("point leaked into typemap")
+	}
+}
+
+func TestInternSharing(t *testing.T) {
+	r := NewTypeRegistry()
+	list := func(elem *ReeType) *ReeType { return &ReeType{Val: TYPE_LIST, Params: []*ReeType{elem}} }
+
+	a := r.Intern(list(list(r.Lookup("int"))))
+	b := r.Intern(list(list(r.Lookup("int"))))
+	if a != b {
+		t.Errorf("identical types interned to different pointers")
+	}
+	if a.Params[0] != b.Params[0] {
+		t.Errorf("identical parameters were not canonicalized")
+	}
+	if r.Intern(list(r.Lookup("bool"))) == r.Intern(list(r.Lookup("int"))) {
+		t.Errorf("(list bool) and (list int) interned together")
+	}
+	if r.Intern(&ReeType{Val: TYPE_CUSTOM, Name: "int"}) != r.Lookup("int") {
+		t.Errorf("named type did not intern to the registered one")
+	}
+
+	/* type variables are never merged, even with the same name. */
+	x, y := &ReeType{Val: TYPE_VAR, Name: "a"}, &ReeType{Val: TYPE_VAR, Name: "a"}
+	if r.Intern(x) != x || r.Intern(list(x)) == r.Intern(list(y)) {
+		t.Errorf("type variables from different annotations were merged")
+	}
+
+	/* recursive types must terminate. */
+	rec := &ReeType{Val: TYPE_CONS}
+	rec.Params = []*ReeType{r.Lookup("int"), rec}
+	if r.Intern(rec) != rec {
+		t.Errorf("recursive type did not intern to itself")
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	r := NewTypeRegistry()
+	want := r.Intern(&ReeType{Val: TYPE_VECTOR, Params: []*ReeType{r.Lookup("char")}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if r.Lookup("int") == nil {
+					t.Error("Lookup(int) returned nil")
+					return
+				}
+				got := r.Intern(&ReeType{Val: TYPE_VECTOR, Params: []*ReeType{r.Lookup("char")}})
+				if got != want {
+					t.Error("concurrent Intern returned a different pointer")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestInternCanonicalReadOnly(t *testing.T) {
+	r := NewTypeRegistry()
+	want := r.Intern(&ReeType{Val: TYPE_LIST, Params: []*ReeType{
+		{Val: TYPE_BOX, Params: []*ReeType{r.Lookup("int")}},
+	}})
+
+	/* interning a canonical type again must not write to it while others read it. */
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(reader bool) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if reader {
+					if got := want.Params[0].String(); got != "(box int)" {
+						t.Errorf("Params[0] prints as %s; want (box int)", got)
+						return
+					}
+					if !Subtype(want, want) {
+						t.Error("canonical type is not a subtype of itself")
+						return
+					}
+				} else if r.Intern(want) != want {
+					t.Error("Intern of a canonical type returned a different pointer")
+					return
+				}
+			}
+		}(i%2 == 0)
+	}
+	wg.Wait()
+}
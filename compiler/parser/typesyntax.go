@@ -0,0 +1,378 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/ReewassSquared/ReeCurse/compiler/lexer"
+)
+
+/**
+ * TYPE = name                       a native or registered type: int, bool, ...
+ *      | any                        the unknown (dynamic) type
+ *      | var                        any other identifier is a type variable
+ *      | (-> TYPE* TYPE)            function; the last TYPE is the result
+ *      | (list TYPE)  | (box TYPE)  | (vector TYPE)
+ *      | (cons TYPE TYPE)           | (map TYPE TYPE)
+ *      | (rec var TYPE)             recursive type; var stands for the whole
+ */
+
+type typector struct {
+	val TypeVal
+	n   int //number of type arguments
+}
+
+var typectors map[string]typector = map[string]typector{
+	"list":   {TYPE_LIST, 1},
+	"box":    {TYPE_BOX, 1},
+	"vector": {TYPE_VECTOR, 1},
+	"cons":   {TYPE_CONS, 2},
+	"map":    {TYPE_MAP, 2},
+}
+
+/* ParseType parses the type starting at the next token. */
+func (p *ReeParser) ParseType() *ReeType {
+	p.Next()
+	return p.typeexpr()
+}
+
+/* typeexpr parses the type starting at the current token. */
+func (p *ReeParser) typeexpr() *ReeType {
+	return p.Types.Intern(p.typ(map[string]*ReeType{}))
+}
+
+/* typ parses a type at the current token, with vars in scope. */
+func (p *ReeParser) typ(vars map[string]*ReeType) *ReeType {
+	tok := p.Tok
+	if tok.Tok == TOK_LPAREN {
+		return p.typeapp(vars)
+	}
+	/* box and cons lex as operators, so go by the text. */
+	if ctor, ok := typectors[tok.Value]; ok && (tok.Tok == TOK_IDENT || tok.Tok > OP_UNDEF) {
+		p.Errorf(fmt.Sprintf("%s expects %d type argument(s)", tok.Value, ctor.n))
+		return &ReeType{Val: TYPE_UNK}
+	}
+	if tok.Tok != TOK_IDENT {
+		p.Errorf(fmt.Sprintf("unexpected %s in type", tok.Tok.String()))
+		return &ReeType{Val: TYPE_UNK}
+	}
+	if tok.Value == "->" || tok.Value == "rec" {
+		p.Errorf(fmt.Sprintf("unexpected %s in type", tok.Value))
+		return &ReeType{Val: TYPE_UNK}
+	}
+	if v, ok := vars[tok.Value]; ok {
+		return v
+	}
+	if tok.Value == "any" {
+		return &ReeType{Val: TYPE_UNK}
+	}
+	if typ := p.Types.Lookup(tok.Value); typ != nil {
+		return typ
+	}
+	v := &ReeType{Val: TYPE_VAR, Name: tok.Value}
+	vars[tok.Value] = v
+	return v
+}
+
+/* typeapp parses a parenthesized type; the current token is the TOK_LPAREN. */
+func (p *ReeParser) typeapp(vars map[string]*ReeType) *ReeType {
+	head := p.Next()
+	switch head.Value {
+	case "->":
+		args := p.types(vars)
+		if len(args) == 0 {
+			p.Errorf("function type has no result type")
+			return &ReeType{Val: TYPE_UNK}
+		}
+		return &ReeType{Val: TYPE_FUNC, Params: args}
+	case "rec":
+		name := p.Next()
+		if name.Tok != TOK_IDENT {
+			p.Errorf(fmt.Sprintf("unexpected %s; wanted %s", name.Tok.String(), TOK_IDENT.String()))
+			p.skip()
+			return &ReeType{Val: TYPE_UNK}
+		}
+		self := &ReeType{}
+		scope := make(map[string]*ReeType, len(vars)+1)
+		for k, v := range vars {
+			scope[k] = v
+		}
+		scope[name.Value] = self
+		p.Next()
+		body := p.typ(scope)
+		p.close()
+		if body == self {
+			p.Errorf(fmt.Sprintf("recursive type %s is only itself", name.Value))
+			return &ReeType{Val: TYPE_UNK}
+		}
+		/* copying a leaf would make a second, unrelated variable or name. */
+		if leaftype(body) {
+			return body
+		}
+		*self = *body
+		return self
+	}
+
+	ctor, ok := typectors[head.Value]
+	if !ok {
+		p.Errorf(fmt.Sprintf("unknown type constructor %s", head.Value))
+		p.skip()
+		return &ReeType{Val: TYPE_UNK}
+	}
+	args := p.types(vars)
+	if len(args) != ctor.n {
+		p.Errorf(fmt.Sprintf("%s expects %d type argument(s), got %d", head.Value, ctor.n, len(args)))
+		return &ReeType{Val: TYPE_UNK}
+	}
+	return &ReeType{Val: ctor.val, Params: args}
+}
+
+/* types parses types up to and including the closing TOK_RPAREN. */
+func (p *ReeParser) types(vars map[string]*ReeType) []*ReeType {
+	typs := []*ReeType{}
+	for p.Next(); !p.got(TOK_RPAREN); p.Next() {
+		if p.got(TOK_EOF) {
+			p.Errorf(fmt.Sprintf("unexpected %s; wanted %s", TOK_EOF.String(), TOK_RPAREN.String()))
+			break
+		}
+		typs = append(typs, p.typ(vars))
+	}
+	return typs
+}
+
+/**
+ * String prints typ in the syntax above. Type variables are renamed to
+ * a, b, c, ... in order of appearance, so alpha-equivalent types print
+ * the same; cycles are printed as (rec var ...).
+ */
+func (typ *ReeType) String() string {
+	pr := &typeprinter{
+		names:   map[*ReeType]string{},
+		recs:    map[*ReeType]bool{},
+		binders: map[*ReeType]string{},
+	}
+	pr.scan(typ, map[*ReeType]bool{}, map[*ReeType]bool{})
+	var b strings.Builder
+	pr.print(&b, typ)
+	return b.String()
+}
+
+type typeprinter struct {
+	names   map[*ReeType]string //type variables seen so far
+	recs    map[*ReeType]bool   //types some cycle leads back to
+	binders map[*ReeType]string //rec binders currently in scope
+	fresh   int
+}
+
+/* leaf types print by name and are never descended into. */
+func leaftype(typ *ReeType) bool {
+	return typ == nil || typ.Val == TYPE_VAR || typ.Val == TYPE_UNK || typ.Name != ""
+}
+
+/* scan finds the types that need a rec binder: those on a cycle. */
+func (pr *typeprinter) scan(typ *ReeType, onpath, done map[*ReeType]bool) {
+	if leaftype(typ) {
+		return
+	}
+	if onpath[typ] {
+		pr.recs[typ] = true
+		return
+	}
+	if done[typ] {
+		return
+	}
+	onpath[typ] = true
+	for _, sub := range typ.Params {
+		pr.scan(sub, onpath, done)
+	}
+	for _, sub := range typ.Types {
+		pr.scan(sub, onpath, done)
+	}
+	delete(onpath, typ)
+	done[typ] = true
+}
+
+func (pr *typeprinter) name() string {
+	n := pr.fresh
+	pr.fresh++
+	if n < 26 {
+		return string(rune('a' + n))
+	}
+	return fmt.Sprintf("t%d", n)
+}
+
+func (pr *typeprinter) print(b *strings.Builder, typ *ReeType) {
+	switch {
+	case typ == nil, typ.Val == TYPE_UNK:
+		b.WriteString("any")
+		return
+	case typ.Val == TYPE_VAR:
+		if _, ok := pr.names[typ]; !ok {
+			pr.names[typ] = pr.name()
+		}
+		b.WriteString(pr.names[typ])
+		return
+	case typ.Name != "":
+		b.WriteString(typ.Name)
+		return
+	}
+
+	if name, ok := pr.binders[typ]; ok {
+		b.WriteString(name)
+		return
+	}
+	if pr.recs[typ] {
+		name := pr.name()
+		pr.binders[typ] = name
+		defer delete(pr.binders, typ)
+		b.WriteString("(rec " + name + " ")
+		defer b.WriteString(")")
+	}
+
+	head := typ.Val.String()
+	if typ.Val == TYPE_FUNC {
+		head = "->"
+	}
+	for name, ctor := range typectors {
+		if ctor.val == typ.Val {
+			head = name
+		}
+	}
+	if len(typ.Params) == 0 {
+		b.WriteString(head)
+		return
+	}
+	b.WriteString("(" + head)
+	for _, sub := range typ.Params {
+		b.WriteString(" ")
+		pr.print(b, sub)
+	}
+	b.WriteString(")")
+}
+
+/**
+ * Equal reports whether a and b are the same type, up to a consistent
+ * renaming of type variables. Named types compare by name; recursive
+ * types compare equal when no difference is found along any cycle.
+ */
+func Equal(a, b *ReeType) bool {
+	return newtypecmp().equal(a, b)
+}
+
+/**
+ * Subtype reports whether a value of type a can be used where b is
+ * expected. Everything is a subtype of any; functions are contravariant
+ * in their arguments and covariant in their result, boxes are invariant,
+ * and the other constructors are covariant.
+ */
+func Subtype(a, b *ReeType) bool {
+	return newtypecmp().sub(a, b)
+}
+
+type typecmp struct {
+	eqs, subs    map[[2]*ReeType]bool //pairs assumed to hold while comparing
+	avars, bvars map[*ReeType]*ReeType
+	flip         bool //sides are swapped, as in function arguments
+}
+
+func newtypecmp() *typecmp {
+	return &typecmp{
+		eqs:   map[[2]*ReeType]bool{},
+		subs:  map[[2]*ReeType]bool{},
+		avars: map[*ReeType]*ReeType{},
+		bvars: map[*ReeType]*ReeType{},
+	}
+}
+
+/**
+ * bind pairs up a variable of the left type with one of the right type,
+ * failing if either is already paired elsewhere.
+ */
+func (c *typecmp) bind(a, b *ReeType) bool {
+	if c.flip {
+		a, b = b, a
+	}
+	if x, ok := c.avars[a]; ok {
+		return x == b
+	}
+	if y, ok := c.bvars[b]; ok {
+		return y == a
+	}
+	c.avars[a], c.bvars[b] = b, a
+	return true
+}
+
+func (c *typecmp) equal(a, b *ReeType) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Val == TYPE_VAR || b.Val == TYPE_VAR {
+		return a.Val == b.Val && c.bind(a, b)
+	}
+	if a.Name != "" || b.Name != "" {
+		return a.Val == b.Val && a.Name == b.Name
+	}
+	pair := [2]*ReeType{a, b}
+	if c.eqs[pair] {
+		return true
+	}
+	c.eqs[pair] = true
+
+	if a.Val != b.Val || len(a.Params) != len(b.Params) || len(a.Types) != len(b.Types) {
+		return false
+	}
+	for i := range a.Params {
+		if !c.equal(a.Params[i], b.Params[i]) {
+			return false
+		}
+	}
+	for i := range a.Types {
+		if !c.equal(a.Types[i], b.Types[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *typecmp) sub(a, b *ReeType) bool {
+	if b == nil || b.Val == TYPE_UNK {
+		return true
+	}
+	if a == nil || a.Val == TYPE_UNK {
+		return false
+	}
+	if a.Val == TYPE_VAR || b.Val == TYPE_VAR || a.Name != "" || b.Name != "" {
+		return c.equal(a, b)
+	}
+	pair := [2]*ReeType{a, b}
+	if c.subs[pair] {
+		return true
+	}
+	c.subs[pair] = true
+
+	if a.Val != b.Val || len(a.Params) != len(b.Params) || len(a.Types) != len(b.Types) {
+		return false
+	}
+	for i := range a.Types {
+		if !c.equal(a.Types[i], b.Types[i]) {
+			return false
+		}
+	}
+	for i := range a.Params {
+		var ok bool
+		switch {
+		case a.Val == TYPE_BOX:
+			ok = c.equal(a.Params[i], b.Params[i])
+		case a.Val == TYPE_FUNC && i < len(a.Params)-1:
+			c.flip = !c.flip
+			ok = c.sub(b.Params[i], a.Params[i])
+			c.flip = !c.flip
+		default:
+			ok = c.sub(a.Params[i], b.Params[i])
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
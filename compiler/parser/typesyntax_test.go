@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/ReewassSquared/ReeCurse/compiler/lexer"
+)
+
+func parseType(t *testing.T, src string) *ReeType {
+	t.Helper()
+	p := &ReeParser{ReeLexer: &ReeLexer{}, Types: NewTypeRegistry()}
+	p.Init(strings.NewReader(src))
+	return p.ParseType()
+}
+
+func TestTypeString(t *testing.T) {
+	tests := []struct{ src, want string }{
+		{"int", "int"},
+		{"(-> int (list int) bool)", "(-> int (list int) bool)"},
+		{"(list x)", "(list a)"},
+		{"(-> q r q)", "(-> a b a)"},
+		{"(map string (box any))", "(map string (box any))"},
+		{"(rec t (cons int t))", "(rec a (cons int a))"},
+		{"(-> a (rec t a))", "(-> a a)"},
+		{"(rec t int)", "int"},
+
+		/* malformed types become any */
+		{"(list +)", "(list any)"},
+		{"(list box)", "(list any)"},
+		{"list", "any"},
+		{"(-> int ->)", "(-> int any)"},
+	}
+	for _, tt := range tests {
+		if got := parseType(t, tt.src).String(); got != tt.want {
+			t.Errorf("%s prints as %s; want %s", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestTypeRelations(t *testing.T) {
+	tests := []struct {
+		a, b       string
+		equal, sub bool
+	}{
+		/* alpha-equivalence */
+		{"(-> x y x)", "(-> a b a)", true, true},
+		{"(-> x y x)", "(-> a b b)", false, false},
+		{"(cons x x)", "(cons a b)", false, false},
+
+		/* variables pair up consistently across contravariant arguments */
+		{"(-> a a)", "(-> b c)", false, false},
+		{"(-> b c)", "(-> a a)", false, false},
+		{"(-> a b)", "(-> c d)", true, true},
+
+		/* recursive types are compared by unfolding */
+		{"(rec t (cons int t))", "(rec u (cons int (cons int u)))", true, true},
+		{"(rec t (cons int t))", "(rec u (cons int (cons bool u)))", false, false},
+		{"(rec t (list t))", "(list (rec u (list u)))", true, true},
+
+		/* any is the top type */
+		{"(list int)", "(list any)", false, true},
+		{"(list any)", "(list int)", false, false},
+
+		/* boxes are invariant */
+		{"(box int)", "(box any)", false, false},
+		{"(box int)", "(box int)", true, true},
+
+		/* functions: contravariant arguments, covariant result */
+		{"(-> any int)", "(-> int any)", false, true},
+		{"(-> int int)", "(-> any int)", false, false},
+	}
+	for _, tt := range tests {
+		a, b := parseType(t, tt.a), parseType(t, tt.b)
+		if got := Equal(a, b); got != tt.equal {
+			t.Errorf("Equal(%s, %s) = %v; want %v", tt.a, tt.b, got, tt.equal)
+		}
+		if got := Subtype(a, b); got != tt.sub {
+			t.Errorf("Subtype(%s, %s) = %v; want %v", tt.a, tt.b, got, tt.sub)
+		}
+	}
+}
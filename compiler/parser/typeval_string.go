@@ -17,11 +17,16 @@ func _() {
 	_ = x[TYPE_BOX-6]
 	_ = x[TYPE_CONS-7]
 	_ = x[TYPE_LIST-8]
+	_ = x[TYPE_VECTOR-9]
+	_ = x[TYPE_MAP-10]
+	_ = x[TYPE_FUNC-11]
+	_ = x[TYPE_VAR-12]
+	_ = x[TYPE_CUSTOM-13]
 }
 
-const _TypeVal_name = "TYPE_UNKTYPE_INTTYPE_STRINGTYPE_CHARTYPE_BOOLEANTYPE_SYMBOLTYPE_BOXTYPE_CONSTYPE_LIST"
+const _TypeVal_name = "TYPE_UNKTYPE_INTTYPE_STRINGTYPE_CHARTYPE_BOOLEANTYPE_SYMBOLTYPE_BOXTYPE_CONSTYPE_LISTTYPE_VECTORTYPE_MAPTYPE_FUNCTYPE_VARTYPE_CUSTOM"
 
-var _TypeVal_index = [...]uint8{0, 8, 16, 27, 36, 48, 59, 67, 76, 85}
+var _TypeVal_index = [...]uint8{0, 8, 16, 27, 36, 48, 59, 67, 76, 85, 96, 104, 113, 121, 132}
 
 func (i TypeVal) String() string {
 	if i >= TypeVal(len(_TypeVal_index)-1) {